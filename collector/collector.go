@@ -0,0 +1,357 @@
+// Package collector implements a prometheus.Collector that scrapes a
+// Gitlab instance on demand and reports it as properly typed metrics,
+// replacing the exporter's earlier fmt.Sprintf-built text format.
+package collector
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/apolikamixitos/prometheus-gitlab-exporter/config"
+	"github.com/apolikamixitos/prometheus-gitlab-exporter/gitlab"
+)
+
+const namespace = "gitlab"
+
+// Collector scrapes a Gitlab instance each time Prometheus collects it,
+// leaving out of its metrics the projects the config's allow/deny lists
+// reject and capping the number of projects reported to avoid unbounded
+// label cardinality.
+type Collector struct {
+	client         *gitlab.Client
+	filter         config.ProjectFilter
+	maxCardinality int
+
+	scrapeDuration        prometheus.Histogram
+	apiRequestsTotal      *prometheus.CounterVec
+	stars                 *prometheus.GaugeVec
+	forks                 *prometheus.GaugeVec
+	issuesOpen            *prometheus.GaugeVec
+	commitCount           *prometheus.GaugeVec
+	storageSize           *prometheus.GaugeVec
+	repositorySize        *prometheus.GaugeVec
+	lfsObjectSize         *prometheus.GaugeVec
+	jobArtifactsSize      *prometheus.GaugeVec
+	lastActivity          *prometheus.GaugeVec
+	mergeRequestState     *prometheus.GaugeVec
+	pipelineStatus        *prometheus.GaugeVec
+	pipelineDuration      *prometheus.HistogramVec
+	jobDuration           *prometheus.HistogramVec
+	deploymentStatus      *prometheus.GaugeVec
+	commitStatus          *prometheus.GaugeVec
+	releaseCount          *prometheus.GaugeVec
+	mrStateTransitions    *prometheus.CounterVec
+	issueStateTransitions *prometheus.CounterVec
+}
+
+// New builds a Collector. cfg's allow/deny lists and max cardinality are
+// applied on every Collect call.
+func New(client *gitlab.Client, cfg *config.Config) *Collector {
+	projectLabels := []string{"project", "namespace", "id"}
+
+	return &Collector{
+		client:         client,
+		filter:         cfg.Projects,
+		maxCardinality: cfg.MaxCardinality,
+
+		scrapeDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "exporter_scrape_duration_seconds",
+			Help:      "Time taken to collect all project metrics from Gitlab.",
+		}),
+		apiRequestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "exporter_api_requests_total",
+			Help:      "Number of Gitlab API requests made, partitioned by whether the response was served from cache (a 304 Not Modified).",
+		}, []string{"cache_hit"}),
+		stars: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_stars",
+			Help:      "Number of stars a project has.",
+		}, projectLabels),
+		forks: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_forks",
+			Help:      "Number of forks a project has.",
+		}, projectLabels),
+		issuesOpen: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_issues_open",
+			Help:      "Number of open issues on a project.",
+		}, projectLabels),
+		commitCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_commit_count",
+			Help:      "Number of commits on a project's default branch.",
+		}, projectLabels),
+		storageSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_storage_size_bytes",
+			Help:      "Total storage used by a project, in bytes.",
+		}, projectLabels),
+		repositorySize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_repository_size_bytes",
+			Help:      "Size of a project's git repository, in bytes.",
+		}, projectLabels),
+		lfsObjectSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_lfs_object_size_bytes",
+			Help:      "Size of a project's LFS objects, in bytes.",
+		}, projectLabels),
+		jobArtifactsSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_job_artifacts_size_bytes",
+			Help:      "Size of a project's stored job artifacts, in bytes.",
+		}, projectLabels),
+		lastActivity: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "project_last_activity_timestamp_seconds",
+			Help:      "Unix timestamp of the last activity on a project.",
+		}, projectLabels),
+		mergeRequestState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "merge_request_state",
+			Help:      "A merge request in a given state, merge status and target branch.",
+		}, append(projectLabels, "state", "merge_status", "target_branch")),
+		pipelineStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pipeline_status",
+			Help:      "A pipeline run in a given status for a ref.",
+		}, append(projectLabels, "ref", "status")),
+		pipelineDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "pipeline_duration_seconds",
+			Help:      "Duration of pipeline runs.",
+			Buckets:   prometheus.ExponentialBuckets(10, 2, 10),
+		}, append(projectLabels, "ref")),
+		jobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "job_duration_seconds",
+			Help:      "Duration of CI job runs.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		}, append(projectLabels, "stage", "name")),
+		deploymentStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "deployment_status",
+			Help:      "A deployment to an environment in a given status.",
+		}, append(projectLabels, "environment", "status")),
+		commitStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "commit_status",
+			Help:      "A commit status reported for a pipeline's commit, in a given status.",
+		}, append(projectLabels, "sha", "name", "status")),
+		releaseCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "release_count",
+			Help:      "Number of releases published for a project.",
+		}, projectLabels),
+		mrStateTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mr_state_transitions_total",
+			Help:      "Number of merge request state transitions, by source and destination state.",
+		}, append(projectLabels, "from", "to")),
+		issueStateTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "issue_state_transitions_total",
+			Help:      "Number of issue state transitions, by source and destination state.",
+		}, append(projectLabels, "from", "to")),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.vecs() {
+		collector.Describe(ch)
+	}
+	c.apiRequestsTotal.Describe(ch)
+	ch <- c.scrapeDuration.Desc()
+}
+
+// Collect implements prometheus.Collector, scraping Gitlab synchronously
+// on every call.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	start := time.Now()
+	defer func() {
+		c.scrapeDuration.Observe(time.Since(start).Seconds())
+		ch <- c.scrapeDuration
+	}()
+
+	for _, collector := range c.vecs() {
+		collector.Reset()
+	}
+
+	projects, err := c.client.Collect()
+
+	hits, misses := c.client.DrainRequestStats()
+	c.apiRequestsTotal.WithLabelValues("true").Add(float64(hits))
+	c.apiRequestsTotal.WithLabelValues("false").Add(float64(misses))
+	c.apiRequestsTotal.Collect(ch)
+
+	if err != nil {
+		log.Printf("collector: scraping gitlab: %v", err)
+		return
+	}
+
+	reported := 0
+	for _, project := range projects {
+		if !c.filter.Allowed(project.PathWithNamespace) {
+			continue
+		}
+		if reported >= c.maxCardinality {
+			log.Printf("collector: max_cardinality %d reached, dropping remaining projects", c.maxCardinality)
+			break
+		}
+		reported++
+		c.observe(project)
+	}
+
+	for _, collector := range c.vecs() {
+		collector.Collect(ch)
+	}
+}
+
+// resettableCollector is the subset of *GaugeVec/*CounterVec/*HistogramVec
+// that vecs() relies on: describing and collecting like any
+// prometheus.Collector, plus Reset so per-scrape state doesn't leak into
+// the next one.
+type resettableCollector interface {
+	prometheus.Collector
+	Reset()
+}
+
+func (c *Collector) vecs() []resettableCollector {
+	return []resettableCollector{
+		c.stars,
+		c.forks,
+		c.issuesOpen,
+		c.commitCount,
+		c.storageSize,
+		c.repositorySize,
+		c.lfsObjectSize,
+		c.jobArtifactsSize,
+		c.lastActivity,
+		c.mergeRequestState,
+		c.pipelineStatus,
+		c.pipelineDuration,
+		c.jobDuration,
+		c.deploymentStatus,
+		c.commitStatus,
+		c.releaseCount,
+		c.mrStateTransitions,
+		c.issueStateTransitions,
+	}
+}
+
+func (c *Collector) observe(project *gitlab.Project) {
+	namespacePath, name := splitPath(project.PathWithNamespace)
+	id := strconv.Itoa(project.Id)
+	labels := prometheus.Labels{"project": name, "namespace": namespacePath, "id": id}
+
+	c.stars.With(labels).Set(float64(project.StarCount))
+	c.forks.With(labels).Set(float64(project.ForkCount))
+	c.issuesOpen.With(labels).Set(float64(project.OpenIssueCount))
+	c.commitCount.With(labels).Set(float64(project.Statistics.CommitCount))
+	c.storageSize.With(labels).Set(float64(project.Statistics.StorageSize))
+	c.repositorySize.With(labels).Set(float64(project.Statistics.RepositorySize))
+	c.lfsObjectSize.With(labels).Set(float64(project.Statistics.LfsObjectSize))
+	c.jobArtifactsSize.With(labels).Set(float64(project.Statistics.JobArtifactsSize))
+	c.lastActivity.With(labels).Set(float64(project.LastActivityAt.Unix()))
+	c.releaseCount.With(labels).Set(float64(len(project.Releases)))
+
+	for _, mr := range project.MergeRequests {
+		c.mergeRequestState.With(mergeLabels(labels, mr)).Set(1)
+		for _, t := range stateTransitions(mr.StateEvents) {
+			c.mrStateTransitions.With(prometheus.Labels{
+				"project": name, "namespace": namespacePath, "id": id, "from": t.from, "to": t.to,
+			}).Add(float64(t.count))
+		}
+	}
+
+	for _, issue := range project.Issues {
+		for _, t := range stateTransitions(issue.StateEvents) {
+			c.issueStateTransitions.With(prometheus.Labels{
+				"project": name, "namespace": namespacePath, "id": id, "from": t.from, "to": t.to,
+			}).Add(float64(t.count))
+		}
+	}
+
+	for _, pipeline := range project.Pipelines {
+		c.pipelineStatus.With(pipelineLabels(labels, pipeline)).Set(1)
+		c.pipelineDuration.With(prometheus.Labels{
+			"project": name, "namespace": namespacePath, "id": id, "ref": pipeline.Ref,
+		}).Observe(float64(pipeline.Duration))
+
+		for _, job := range pipeline.Jobs {
+			c.jobDuration.With(prometheus.Labels{
+				"project": name, "namespace": namespacePath, "id": id, "stage": job.Stage, "name": job.Name,
+			}).Observe(job.Duration)
+		}
+
+		for _, status := range pipeline.CommitStatuses {
+			c.commitStatus.With(prometheus.Labels{
+				"project": name, "namespace": namespacePath, "id": id,
+				"sha": status.Sha, "name": status.Name, "status": status.Status,
+			}).Set(1)
+		}
+	}
+
+	for _, environment := range project.Environments {
+		for _, deployment := range environment.Deployments {
+			c.deploymentStatus.With(prometheus.Labels{
+				"project": name, "namespace": namespacePath, "id": id,
+				"environment": environment.Name, "status": deployment.Status,
+			}).Set(1)
+		}
+	}
+}
+
+func mergeLabels(base prometheus.Labels, mr gitlab.MergeRequest) prometheus.Labels {
+	labels := prometheus.Labels{"state": mr.State, "merge_status": mr.MergeStatus, "target_branch": mr.TargetBranch}
+	for k, v := range base {
+		labels[k] = v
+	}
+	return labels
+}
+
+func pipelineLabels(base prometheus.Labels, pipeline gitlab.Pipeline) prometheus.Labels {
+	labels := prometheus.Labels{"ref": pipeline.Ref, "status": pipeline.Status}
+	for k, v := range base {
+		labels[k] = v
+	}
+	return labels
+}
+
+type transition struct {
+	from, to string
+	count    int
+}
+
+// stateTransitions collapses a resource's ordered state event history into
+// counts per (from, to) pair, e.g. opened->closed: 2, closed->reopened: 1.
+func stateTransitions(events []gitlab.ResourceStateEvent) []transition {
+	counts := make(map[[2]string]int)
+	for i := 1; i < len(events); i++ {
+		counts[[2]string{events[i-1].State, events[i].State}]++
+	}
+
+	transitions := make([]transition, 0, len(counts))
+	for pair, count := range counts {
+		transitions = append(transitions, transition{from: pair[0], to: pair[1], count: count})
+	}
+	return transitions
+}
+
+// splitPath splits "group/subgroup/project" into its namespace
+// ("group/subgroup") and project name ("project").
+func splitPath(pathWithNamespace string) (namespacePath, name string) {
+	idx := strings.LastIndex(pathWithNamespace, "/")
+	if idx == -1 {
+		return "", pathWithNamespace
+	}
+	return pathWithNamespace[:idx], pathWithNamespace[idx+1:]
+}
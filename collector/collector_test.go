@@ -0,0 +1,65 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/apolikamixitos/prometheus-gitlab-exporter/gitlab"
+)
+
+func TestStateTransitionsCountsConsecutivePairs(t *testing.T) {
+	events := []gitlab.ResourceStateEvent{
+		{State: "opened"},
+		{State: "closed"},
+		{State: "reopened"},
+		{State: "closed"},
+	}
+
+	transitions := stateTransitions(events)
+
+	counts := make(map[[2]string]int)
+	for _, tr := range transitions {
+		counts[[2]string{tr.from, tr.to}] = tr.count
+	}
+
+	want := map[[2]string]int{
+		{"opened", "closed"}:   1,
+		{"closed", "reopened"}: 1,
+		{"reopened", "closed"}: 1,
+	}
+	if len(counts) != len(want) {
+		t.Fatalf("stateTransitions() returned %d distinct pairs, want %d", len(counts), len(want))
+	}
+	for pair, count := range want {
+		if counts[pair] != count {
+			t.Errorf("count for %v = %d, want %d", pair, counts[pair], count)
+		}
+	}
+}
+
+func TestStateTransitionsOnFewerThanTwoEvents(t *testing.T) {
+	if got := stateTransitions(nil); len(got) != 0 {
+		t.Errorf("stateTransitions(nil) = %v, want empty", got)
+	}
+	if got := stateTransitions([]gitlab.ResourceStateEvent{{State: "opened"}}); len(got) != 0 {
+		t.Errorf("stateTransitions() with one event = %v, want empty", got)
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		path          string
+		wantNamespace string
+		wantName      string
+	}{
+		{"group/subgroup/project", "group/subgroup", "project"},
+		{"group/project", "group", "project"},
+		{"project", "", "project"},
+	}
+
+	for _, tt := range tests {
+		namespace, name := splitPath(tt.path)
+		if namespace != tt.wantNamespace || name != tt.wantName {
+			t.Errorf("splitPath(%q) = (%q, %q), want (%q, %q)", tt.path, namespace, name, tt.wantNamespace, tt.wantName)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the exporter's YAML configuration file, typically named
+// config.yml and passed via the -config flag.
+type Config struct {
+	GitlabURL      string        `yaml:"gitlab_url"`
+	Token          string        `yaml:"token"`
+	AuthMethod     string        `yaml:"auth_method"`
+	CACertFile     string        `yaml:"ca_cert_file"`
+	ProxyURL       string        `yaml:"proxy_url"`
+	Timeout        string        `yaml:"timeout"`
+	Projects       ProjectFilter `yaml:"projects"`
+	MaxCardinality int           `yaml:"max_cardinality"`
+}
+
+// GitlabTimeout parses Timeout (e.g. "30s"), returning zero when unset so
+// the gitlab package falls back to its own default.
+func (c *Config) GitlabTimeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("config: parsing timeout %q: %w", c.Timeout, err)
+	}
+	return d, nil
+}
+
+// ProjectFilter controls which project paths the exporter reports metrics
+// for. Deny takes precedence over Allow, and an empty Allow list means
+// "everything not denied". Filtered-out projects are still fetched from
+// Gitlab on every scrape; they are only left out of the metrics.
+type ProjectFilter struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// Allowed reports whether a project path (e.g. "group/subgroup/project")
+// should be reported, matching entries as shell globs against the full
+// path.
+func (f ProjectFilter) Allowed(projectPath string) bool {
+	for _, pattern := range f.Deny {
+		if matches(pattern, projectPath) {
+			return false
+		}
+	}
+	if len(f.Allow) == 0 {
+		return true
+	}
+	for _, pattern := range f.Allow {
+		if matches(pattern, projectPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(pattern, projectPath string) bool {
+	ok, err := path.Match(pattern, projectPath)
+	return err == nil && ok
+}
+
+const defaultMaxCardinality = 10000
+
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces every ${VAR} reference in data with the value of the
+// named environment variable, so secrets like a Gitlab token don't need
+// to be written into the config file in plain text. A reference to an
+// unset variable expands to an empty string.
+func expandEnv(data []byte) []byte {
+	return envVarPattern.ReplaceAllFunc(data, func(match []byte) []byte {
+		name := envVarPattern.FindSubmatch(match)[1]
+		return []byte(os.Getenv(string(name)))
+	})
+}
+
+// Load reads and parses a YAML config file, expanding ${VAR} references
+// against the environment and applying defaults for unset fields.
+func Load(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", configPath, err)
+	}
+	data = expandEnv(data)
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("config: parsing %s: %w", configPath, err)
+	}
+
+	if cfg.MaxCardinality <= 0 {
+		cfg.MaxCardinality = defaultMaxCardinality
+	}
+
+	return cfg, nil
+}
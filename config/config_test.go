@@ -0,0 +1,61 @@
+package config
+
+import "testing"
+
+func TestProjectFilterAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ProjectFilter
+		path   string
+		want   bool
+	}{
+		{
+			name:   "empty allow list permits everything not denied",
+			filter: ProjectFilter{},
+			path:   "my-group/my-project",
+			want:   true,
+		},
+		{
+			name:   "path not matching any allow pattern is denied",
+			filter: ProjectFilter{Allow: []string{"my-group/*"}},
+			path:   "other-group/my-project",
+			want:   false,
+		},
+		{
+			name:   "path matching an allow pattern is allowed",
+			filter: ProjectFilter{Allow: []string{"my-group/*"}},
+			path:   "my-group/my-project",
+			want:   true,
+		},
+		{
+			name:   "deny takes precedence over a matching allow pattern",
+			filter: ProjectFilter{Allow: []string{"my-group/*"}, Deny: []string{"my-group/archived-*"}},
+			path:   "my-group/archived-project",
+			want:   false,
+		},
+		{
+			name:   "deny with an empty allow list still denies",
+			filter: ProjectFilter{Deny: []string{"my-group/archived-*"}},
+			path:   "my-group/archived-project",
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Allowed(tt.path); got != tt.want {
+				t.Errorf("Allowed(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN_TEST", "secret-token")
+
+	got := string(expandEnv([]byte("token: ${GITLAB_TOKEN_TEST}\nother: ${GITLAB_TOKEN_TEST_UNSET}\n")))
+	want := "token: secret-token\nother: \n"
+	if got != want {
+		t.Errorf("expandEnv() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package gitlab
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// AuthMethod selects how a Client presents Token to Gitlab.
+type AuthMethod string
+
+const (
+	// AuthMethodPrivateToken sends Token as a PRIVATE-TOKEN header
+	// (personal or project access tokens). This is the default.
+	AuthMethodPrivateToken AuthMethod = "private_token"
+	// AuthMethodOAuth sends Token as an OAuth2 bearer token.
+	AuthMethodOAuth AuthMethod = "oauth"
+	// AuthMethodJobToken sends Token as a JOB-TOKEN header, for running
+	// the exporter from within a Gitlab CI job (Token is typically
+	// $CI_JOB_TOKEN).
+	AuthMethodJobToken AuthMethod = "job_token"
+)
+
+// newGitlabClient builds the underlying go-gitlab client for cfg's
+// AuthMethod, wiring in httpClient so every request picks up its
+// timeout, proxy and TLS settings.
+func newGitlabClient(cfg Config, httpClient *http.Client) (*gogitlab.Client, error) {
+	opts := []gogitlab.ClientOptionFunc{
+		gogitlab.WithBaseURL(cfg.BaseURL),
+		gogitlab.WithHTTPClient(httpClient),
+	}
+
+	switch cfg.AuthMethod {
+	case AuthMethodOAuth:
+		return gogitlab.NewOAuthClient(cfg.Token, opts...)
+	case AuthMethodJobToken:
+		return gogitlab.NewJobClient(cfg.Token, opts...)
+	default:
+		return gogitlab.NewClient(cfg.Token, opts...)
+	}
+}
+
+// buildHTTPClient assembles the *http.Client used for every request Client
+// makes, applying cfg's timeout, proxy and custom CA bundle.
+func (c Config) buildHTTPClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	proxyFunc := http.ProxyFromEnvironment
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+		proxyFunc = http.ProxyURL(proxyURL)
+	}
+	transport.Proxy = proxyFunc
+
+	if c.CACertFile != "" {
+		pool, err := loadCACertPool(c.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &http.Client{Transport: transport, Timeout: c.Timeout}, nil
+}
+
+// loadCACertPool reads a PEM-encoded CA bundle from path, for verifying a
+// self-hosted Gitlab instance's (typically self-signed) TLS certificate.
+func loadCACertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading ca_cert_file %s: %w", path, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("ca_cert_file %s contains no usable certificates", path)
+	}
+	return pool, nil
+}
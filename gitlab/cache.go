@@ -0,0 +1,56 @@
+package gitlab
+
+import (
+	"sync"
+	"time"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// cacheEntry records what a previous scrape learned about a cached
+// endpoint: the ETag/Last-Modified to make the next request conditional,
+// and the latest updated_at timestamp seen, used to ask Gitlab for only
+// what changed since.
+type cacheEntry struct {
+	ETag         string
+	LastModified string
+	UpdatedAfter time.Time
+}
+
+// conditionalOptions returns the go-gitlab request options that make a
+// request conditional on this entry's cached ETag/Last-Modified, if any
+// are known.
+func (e cacheEntry) conditionalOptions() []gogitlab.RequestOptionFunc {
+	var opts []gogitlab.RequestOptionFunc
+	if e.ETag != "" {
+		opts = append(opts, gogitlab.WithHeader("If-None-Match", e.ETag))
+	}
+	if e.LastModified != "" {
+		opts = append(opts, gogitlab.WithHeader("If-Modified-Since", e.LastModified))
+	}
+	return opts
+}
+
+// endpointCache is a per-key ETag/Last-Modified/updated_after cache shared
+// across scrapes by a single Client, keyed by caller-defined strings such
+// as "projects" or "project/123/merge_requests".
+type endpointCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newEndpointCache() *endpointCache {
+	return &endpointCache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *endpointCache) get(key string) cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key]
+}
+
+func (c *endpointCache) set(key string, e cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = e
+}
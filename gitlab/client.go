@@ -0,0 +1,132 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// Config controls how Client talks to a Gitlab instance.
+type Config struct {
+	// BaseURL is the Gitlab instance URL, e.g. https://gitlab.com.
+	BaseURL string
+	// Token is the credential presented to Gitlab, interpreted according
+	// to AuthMethod.
+	Token string
+	// AuthMethod selects how Token is presented to Gitlab. Defaults to
+	// AuthMethodPrivateToken.
+	AuthMethod AuthMethod
+	// CACertFile optionally loads a PEM-encoded CA bundle for verifying a
+	// self-hosted Gitlab instance's TLS certificate.
+	CACertFile string
+	// ProxyURL, if set, routes requests through an HTTP(S) proxy instead
+	// of the one derived from the environment.
+	ProxyURL string
+	// Timeout bounds how long a single HTTP request may take. Defaults to
+	// 30s.
+	Timeout time.Duration
+	// PerPage is the page size requested from list endpoints (max 100).
+	PerPage int
+	// MaxConcurrency bounds how many pages/projects are fetched at once.
+	MaxConcurrency int
+	// MaxRetries bounds retry attempts for 429/5xx responses.
+	MaxRetries int
+}
+
+func (c Config) withDefaults() Config {
+	if c.AuthMethod == "" {
+		c.AuthMethod = AuthMethodPrivateToken
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 30 * time.Second
+	}
+	if c.PerPage <= 0 {
+		c.PerPage = 100
+	}
+	if c.MaxConcurrency <= 0 {
+		c.MaxConcurrency = 8
+	}
+	if c.MaxRetries <= 0 {
+		c.MaxRetries = 5
+	}
+	return c
+}
+
+// Client wraps a go-gitlab client with the pagination, concurrency and
+// retry behaviour the exporter's collectors rely on. It also caches
+// ETag/Last-Modified/updated_at state across scrapes so that repeat
+// scrapes of an unchanged instance re-fetch as little as possible.
+type Client struct {
+	gl  *gogitlab.Client
+	cfg Config
+
+	cache         *endpointCache
+	projects      *projectStore
+	mergeRequests *mergeRequestStore
+	stats         *requestStats
+}
+
+// NewClient builds a Client using cfg's AuthMethod (a personal access
+// token, an OAuth2 bearer token, or a CI job token), over an HTTP client
+// configured with cfg's timeout, proxy and custom CA bundle.
+func NewClient(cfg Config) (*Client, error) {
+	cfg = cfg.withDefaults()
+
+	httpClient, err := cfg.buildHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("gitlab: building http client: %w", err)
+	}
+
+	gl, err := newGitlabClient(cfg, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("gitlab: creating client: %w", err)
+	}
+	return &Client{
+		gl:            gl,
+		cfg:           cfg,
+		cache:         newEndpointCache(),
+		projects:      newProjectStore(),
+		mergeRequests: newMergeRequestStore(),
+		stats:         newRequestStats(),
+	}, nil
+}
+
+// DrainRequestStats returns the number of cache-hit (304 Not Modified) and
+// cache-miss (fully fetched) API requests made since the last call,
+// resetting both counters to zero. It is intended to be polled once per
+// scrape.
+func (c *Client) DrainRequestStats() (hits, misses int64) {
+	return c.stats.drain()
+}
+
+// withRetry retries fn on 429 and 5xx responses using exponential backoff,
+// and gives up immediately on any other error.
+func (c *Client) withRetry(fn func() (*gogitlab.Response, error)) (*gogitlab.Response, error) {
+	var lastErr error
+	for attempt := 0; attempt < c.cfg.MaxRetries; attempt++ {
+		resp, err := fn()
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if resp == nil || !isRetryable(resp.StatusCode) {
+			return resp, err
+		}
+		time.Sleep(backoff(attempt))
+	}
+	return nil, lastErr
+}
+
+func isRetryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+func backoff(attempt int) time.Duration {
+	d := time.Duration(1<<uint(attempt)) * 250 * time.Millisecond
+	if d > 10*time.Second {
+		d = 10 * time.Second
+	}
+	return d
+}
@@ -0,0 +1,148 @@
+package gitlab
+
+import (
+	"fmt"
+	"sync"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ListEnvironments fetches every environment for a project, along with its
+// deployments.
+func (c *Client) ListEnvironments(projectID int) ([]Environment, error) {
+	var mu sync.Mutex
+	environments := make([]Environment, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListEnvironmentsOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glEnvironments, resp, err := c.gl.Environments.ListEnvironments(projectID, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, e := range glEnvironments {
+				environments = append(environments, convertEnvironment(e))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing environments for project %d (page %d): %w", projectID, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range environments {
+		deployments, err := c.ListDeployments(projectID, environments[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		environments[i].Deployments = deployments
+	}
+
+	return environments, nil
+}
+
+// ListDeployments fetches every deployment for a project's environment.
+func (c *Client) ListDeployments(projectID int, environment string) ([]Deployment, error) {
+	var mu sync.Mutex
+	deployments := make([]Deployment, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListProjectDeploymentsOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+			Environment: gogitlab.String(environment),
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glDeployments, resp, err := c.gl.Deployments.ListProjectDeployments(projectID, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, d := range glDeployments {
+				deployments = append(deployments, convertDeployment(d))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing deployments for project %d environment %q (page %d): %w", projectID, environment, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deployments, nil
+}
+
+// ListCommitStatuses fetches every commit status reported for a commit.
+func (c *Client) ListCommitStatuses(projectID int, sha string) ([]CommitStatus, error) {
+	var mu sync.Mutex
+	statuses := make([]CommitStatus, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.GetCommitStatusesOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glStatuses, resp, err := c.gl.Commits.GetCommitStatuses(projectID, sha, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, s := range glStatuses {
+				statuses = append(statuses, convertCommitStatus(sha, s))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing commit statuses for project %d sha %s (page %d): %w", projectID, sha, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func convertEnvironment(e *gogitlab.Environment) Environment {
+	return Environment{
+		Id:    e.ID,
+		Name:  e.Name,
+		State: e.State,
+	}
+}
+
+func convertDeployment(d *gogitlab.Deployment) Deployment {
+	return Deployment{
+		Id:        d.ID,
+		Status:    d.Status,
+		Ref:       d.Ref,
+		CreatedAt: derefTime(d.CreatedAt),
+	}
+}
+
+func convertCommitStatus(sha string, s *gogitlab.CommitStatus) CommitStatus {
+	return CommitStatus{
+		Sha:    sha,
+		Status: s.Status,
+		Name:   s.Name,
+	}
+}
@@ -0,0 +1,23 @@
+package gitlab
+
+import (
+	"sort"
+	"time"
+)
+
+// derefTime returns the zero time for a nil *time.Time, mirroring how the
+// Gitlab API omits timestamps that have never been set.
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// sortByCreatedAt orders resource state events oldest first, since the
+// API does not guarantee response ordering across pages.
+func sortByCreatedAt(events []ResourceStateEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+}
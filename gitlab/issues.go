@@ -0,0 +1,53 @@
+package gitlab
+
+import (
+	"fmt"
+	"sync"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ListIssues fetches every issue for a project.
+func (c *Client) ListIssues(projectID int) ([]Issue, error) {
+	var mu sync.Mutex
+	issues := make([]Issue, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListProjectIssuesOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glIssues, resp, err := c.gl.Issues.ListProjectIssues(projectID, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, issue := range glIssues {
+				issues = append(issues, convertIssue(issue))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing issues for project %d (page %d): %w", projectID, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func convertIssue(issue *gogitlab.Issue) Issue {
+	return Issue{
+		Iid:       issue.IID,
+		Title:     issue.Title,
+		State:     issue.State,
+		CreatedAt: derefTime(issue.CreatedAt),
+		UpdatedAt: derefTime(issue.UpdatedAt),
+	}
+}
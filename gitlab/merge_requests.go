@@ -0,0 +1,99 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ListMergeRequests fetches every merge request for a project. Once a
+// previous call has seen every merge request at least once, it asks
+// Gitlab for only the ones updated since the last scrape (`updated_after`,
+// `order_by=updated_at`) and sends the cached ETag/Last-Modified as
+// `If-None-Match`/`If-Modified-Since`, merging whatever comes back into an
+// in-memory store so unchanged merge requests are still reported.
+func (c *Client) ListMergeRequests(projectID int) ([]MergeRequest, error) {
+	cacheKey := fmt.Sprintf("project/%d/merge_requests", projectID)
+	cached := c.cache.get(cacheKey)
+
+	var mu sync.Mutex
+	fetched := make([]MergeRequest, 0)
+	latestUpdate := cached.UpdatedAfter
+	notModified := false
+	var etag, lastModified string
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListProjectMergeRequestsOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+		var reqOpts []gogitlab.RequestOptionFunc
+		if !cached.UpdatedAfter.IsZero() {
+			opts.OrderBy = gogitlab.String("updated_at")
+			opts.UpdatedAfter = gogitlab.Time(cached.UpdatedAfter)
+			reqOpts = cached.conditionalOptions()
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			mrs, resp, err := c.gl.MergeRequests.ListProjectMergeRequests(projectID, opts, reqOpts...)
+			if resp != nil && resp.StatusCode == http.StatusNotModified {
+				return resp, nil
+			}
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, mr := range mrs {
+				converted := convertMergeRequest(mr)
+				fetched = append(fetched, converted)
+				if converted.UpdatedAt.After(latestUpdate) {
+					latestUpdate = converted.UpdatedAt
+				}
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing merge requests for project %d (page %d): %w", projectID, page, err)
+		}
+		if resp.StatusCode == http.StatusNotModified {
+			c.stats.recordHit()
+			notModified = true
+			return 1, nil
+		}
+		c.stats.recordMiss()
+		if page == 1 {
+			etag = resp.Header.Get("ETag")
+			lastModified = resp.Header.Get("Last-Modified")
+		}
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if notModified {
+		return c.mergeRequests.all(projectID), nil
+	}
+
+	c.cache.set(cacheKey, cacheEntry{
+		ETag:         etag,
+		LastModified: lastModified,
+		UpdatedAfter: latestUpdate,
+	})
+
+	return c.mergeRequests.merge(projectID, fetched), nil
+}
+
+func convertMergeRequest(mr *gogitlab.MergeRequest) MergeRequest {
+	return MergeRequest{
+		Iid:          mr.IID,
+		Title:        mr.Title,
+		State:        mr.State,
+		MergeStatus:  mr.MergeStatus,
+		TargetBranch: mr.TargetBranch,
+		CreatedAt:    derefTime(mr.CreatedAt),
+		UpdatedAt:    derefTime(mr.UpdatedAt),
+	}
+}
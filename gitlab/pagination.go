@@ -0,0 +1,48 @@
+package gitlab
+
+import "sync"
+
+// fetchPageFunc fetches a single page, appending its results to whatever
+// the caller is accumulating, and reports the total number of pages the
+// API advertised on that response.
+type fetchPageFunc func(page int) (totalPages int, err error)
+
+// paginate fetches page 1 to discover the total page count, then fetches
+// the remaining pages concurrently through a bounded worker pool. It
+// requires fetch to be safe for concurrent use (callers append results
+// under their own mutex).
+func (c *Client) paginate(fetch fetchPageFunc) error {
+	totalPages, err := fetch(1)
+	if err != nil {
+		return err
+	}
+
+	if totalPages <= 1 {
+		return nil
+	}
+
+	sem := make(chan struct{}, c.cfg.MaxConcurrency)
+	var wg sync.WaitGroup
+	errs := make(chan error, totalPages-1)
+
+	for page := 2; page <= totalPages; page++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(page int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, err := fetch(page); err != nil {
+				errs <- err
+			}
+		}(page)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package gitlab
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestPaginateFetchesEveryPage(t *testing.T) {
+	c := &Client{cfg: Config{MaxConcurrency: 4}.withDefaults()}
+
+	var mu sync.Mutex
+	var seen []int
+	const totalPages = 5
+
+	err := c.paginate(func(page int) (int, error) {
+		mu.Lock()
+		seen = append(seen, page)
+		mu.Unlock()
+		return totalPages, nil
+	})
+	if err != nil {
+		t.Fatalf("paginate() error = %v", err)
+	}
+
+	sort.Ints(seen)
+	if len(seen) != totalPages {
+		t.Fatalf("paginate() fetched %d pages, want %d", len(seen), totalPages)
+	}
+	for i, page := range seen {
+		if page != i+1 {
+			t.Errorf("pages fetched = %v, want 1..%d", seen, totalPages)
+			break
+		}
+	}
+}
+
+func TestPaginateStopsAfterSinglePage(t *testing.T) {
+	c := &Client{cfg: Config{MaxConcurrency: 4}.withDefaults()}
+
+	calls := 0
+	err := c.paginate(func(page int) (int, error) {
+		calls++
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatalf("paginate() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("paginate() made %d calls, want 1", calls)
+	}
+}
+
+func TestPaginatePropagatesErrorFromAnyPage(t *testing.T) {
+	c := &Client{cfg: Config{MaxConcurrency: 4}.withDefaults()}
+
+	wantErr := fmt.Errorf("boom")
+	err := c.paginate(func(page int) (int, error) {
+		if page == 3 {
+			return 0, wantErr
+		}
+		return 4, nil
+	})
+	if err == nil {
+		t.Fatal("paginate() error = nil, want non-nil")
+	}
+}
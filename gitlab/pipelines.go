@@ -0,0 +1,139 @@
+package gitlab
+
+import (
+	"fmt"
+	"sync"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ListPipelines fetches every pipeline for a project, along with the jobs
+// that belong to each pipeline.
+func (c *Client) ListPipelines(projectID int) ([]Pipeline, error) {
+	var mu sync.Mutex
+	pipelines := make([]Pipeline, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListProjectPipelinesOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glPipelines, resp, err := c.gl.Pipelines.ListProjectPipelines(projectID, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, p := range glPipelines {
+				pipelines = append(pipelines, convertPipelineInfo(p))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing pipelines for project %d (page %d): %w", projectID, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pipelines {
+		duration, err := c.getPipelineDuration(projectID, pipelines[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		pipelines[i].Duration = duration
+
+		jobs, err := c.ListJobs(projectID, pipelines[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		pipelines[i].Jobs = jobs
+
+		statuses, err := c.ListCommitStatuses(projectID, pipelines[i].Sha)
+		if err != nil {
+			return nil, err
+		}
+		pipelines[i].CommitStatuses = statuses
+	}
+
+	return pipelines, nil
+}
+
+// getPipelineDuration fetches a single pipeline's duration, which
+// ListProjectPipelines doesn't return.
+func (c *Client) getPipelineDuration(projectID, pipelineID int) (int, error) {
+	var duration int
+	_, err := c.withRetry(func() (*gogitlab.Response, error) {
+		pipeline, resp, err := c.gl.Pipelines.GetPipeline(projectID, pipelineID)
+		if err != nil {
+			return resp, err
+		}
+		duration = pipeline.Duration
+		return resp, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("gitlab: getting pipeline %d for project %d: %w", pipelineID, projectID, err)
+	}
+	c.stats.recordMiss()
+	return duration, nil
+}
+
+// ListJobs fetches every job belonging to a pipeline.
+func (c *Client) ListJobs(projectID, pipelineID int) ([]Job, error) {
+	var mu sync.Mutex
+	jobs := make([]Job, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListJobsOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glJobs, resp, err := c.gl.Jobs.ListPipelineJobs(projectID, pipelineID, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, j := range glJobs {
+				jobs = append(jobs, convertJob(j))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing jobs for pipeline %d (page %d): %w", pipelineID, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func convertPipelineInfo(p *gogitlab.PipelineInfo) Pipeline {
+	return Pipeline{
+		Id:        p.ID,
+		Status:    p.Status,
+		Ref:       p.Ref,
+		Sha:       p.SHA,
+		CreatedAt: derefTime(p.CreatedAt),
+		UpdatedAt: derefTime(p.UpdatedAt),
+	}
+}
+
+func convertJob(j *gogitlab.Job) Job {
+	return Job{
+		Id:       j.ID,
+		Name:     j.Name,
+		Stage:    j.Stage,
+		Status:   j.Status,
+		Duration: j.Duration,
+	}
+}
@@ -0,0 +1,207 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+const projectsCacheKey = "projects"
+
+// ListProjects fetches every project visible to the configured token,
+// paginating concurrently once the first page reports how many pages
+// exist. Once a previous call has seen every project at least once, it
+// asks Gitlab for only the projects active since the last scrape
+// (`last_activity_after`) and sends the cached ETag/Last-Modified as
+// `If-None-Match`/`If-Modified-Since`, merging whatever comes back into an
+// in-memory store so unchanged projects are still reported.
+func (c *Client) ListProjects() ([]*Project, error) {
+	cached := c.cache.get(projectsCacheKey)
+
+	var mu sync.Mutex
+	fetched := make([]*Project, 0)
+	latestActivity := cached.UpdatedAfter
+	notModified := false
+	var etag, lastModified string
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListProjectsOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+			Statistics:  gogitlab.Bool(true),
+		}
+		var reqOpts []gogitlab.RequestOptionFunc
+		if !cached.UpdatedAfter.IsZero() {
+			opts.OrderBy = gogitlab.String("last_activity_at")
+			opts.LastActivityAfter = gogitlab.Time(cached.UpdatedAfter)
+			reqOpts = cached.conditionalOptions()
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glProjects, resp, err := c.gl.Projects.ListProjects(opts, reqOpts...)
+			if resp != nil && resp.StatusCode == http.StatusNotModified {
+				return resp, nil
+			}
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, p := range glProjects {
+				project := convertProject(p)
+				fetched = append(fetched, project)
+				if project.LastActivityAt.After(latestActivity) {
+					latestActivity = project.LastActivityAt
+				}
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing projects (page %d): %w", page, err)
+		}
+		if resp.StatusCode == http.StatusNotModified {
+			c.stats.recordHit()
+			notModified = true
+			return 1, nil
+		}
+		c.stats.recordMiss()
+		if page == 1 {
+			etag = resp.Header.Get("ETag")
+			lastModified = resp.Header.Get("Last-Modified")
+		}
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if notModified {
+		return c.projects.all(), nil
+	}
+
+	c.cache.set(projectsCacheKey, cacheEntry{
+		ETag:         etag,
+		LastModified: lastModified,
+		UpdatedAfter: latestActivity,
+	})
+
+	return c.projects.merge(fetched), nil
+}
+
+// Collect fetches all projects and, for each one, the child resources the
+// exporter reports metrics for (merge requests, issues, pipelines,
+// releases, environments), bounding the number of projects processed at
+// once to MaxConcurrency.
+func (c *Client) Collect() ([]*Project, error) {
+	projects, err := c.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	sem := make(chan struct{}, c.cfg.MaxConcurrency)
+	var wg sync.WaitGroup
+	errs := make(chan error, len(projects))
+
+	for _, project := range projects {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(project *Project) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := c.collectProjectResources(project); err != nil {
+				errs <- err
+			}
+		}(project)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return projects, nil
+}
+
+func (c *Client) collectProjectResources(project *Project) error {
+	mergeRequests, err := c.ListMergeRequests(project.Id)
+	if err != nil {
+		return err
+	}
+	for i := range mergeRequests {
+		if !c.mergeRequests.isFresh(project.Id, mergeRequests[i].Iid) {
+			continue
+		}
+		events, err := c.ListMergeRequestStateEvents(project.Id, mergeRequests[i].Iid)
+		if err != nil {
+			return err
+		}
+		mergeRequests[i].StateEvents = events
+		c.mergeRequests.setStateEvents(project.Id, mergeRequests[i].Iid, events)
+	}
+	project.MergeRequests = mergeRequests
+
+	// ListIssues does no caching of its own and returns every issue fresh
+	// on every scrape, so there's no cached/changed distinction to make
+	// here the way there is for merge requests above.
+	issues, err := c.ListIssues(project.Id)
+	if err != nil {
+		return err
+	}
+	for i := range issues {
+		events, err := c.ListIssueStateEvents(project.Id, issues[i].Iid)
+		if err != nil {
+			return err
+		}
+		issues[i].StateEvents = events
+	}
+	project.Issues = issues
+
+	pipelines, err := c.ListPipelines(project.Id)
+	if err != nil {
+		return err
+	}
+	project.Pipelines = pipelines
+
+	releases, err := c.ListReleases(project.Id)
+	if err != nil {
+		return err
+	}
+	project.Releases = releases
+
+	environments, err := c.ListEnvironments(project.Id)
+	if err != nil {
+		return err
+	}
+	project.Environments = environments
+
+	return nil
+}
+
+func convertProject(p *gogitlab.Project) *Project {
+	return &Project{
+		Id:                p.ID,
+		PathWithNamespace: p.PathWithNamespace,
+		StarCount:         p.StarCount,
+		ForkCount:         p.ForksCount,
+		OpenIssueCount:    p.OpenIssuesCount,
+		LastActivityAt:    derefTime(p.LastActivityAt),
+		Statistics:        convertProjectStats(p.Statistics),
+	}
+}
+
+func convertProjectStats(s *gogitlab.Statistics) ProjectStats {
+	if s == nil {
+		return ProjectStats{}
+	}
+	return ProjectStats{
+		CommitCount:      int(s.CommitCount),
+		StorageSize:      int(s.StorageSize),
+		RepositorySize:   int(s.RepositorySize),
+		LfsObjectSize:    int(s.LFSObjectsSize),
+		JobArtifactsSize: int(s.JobArtifactsSize),
+	}
+}
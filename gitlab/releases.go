@@ -0,0 +1,51 @@
+package gitlab
+
+import (
+	"fmt"
+	"sync"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ListReleases fetches every release for a project.
+func (c *Client) ListReleases(projectID int) ([]Release, error) {
+	var mu sync.Mutex
+	releases := make([]Release, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListReleasesOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glReleases, resp, err := c.gl.Releases.ListReleases(projectID, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, r := range glReleases {
+				releases = append(releases, convertRelease(r))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing releases for project %d (page %d): %w", projectID, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+func convertRelease(r *gogitlab.Release) Release {
+	return Release{
+		TagName:    r.TagName,
+		Name:       r.Name,
+		ReleasedAt: derefTime(r.ReleasedAt),
+	}
+}
@@ -0,0 +1,98 @@
+package gitlab
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ResourceStateEvent records a single state change ("opened", "closed",
+// "reopened", "merged") on an issue or merge request, per
+// https://docs.gitlab.com/ee/api/resource_state_events.html.
+type ResourceStateEvent struct {
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListMergeRequestStateEvents fetches the state change history of a merge
+// request, ordered oldest first.
+func (c *Client) ListMergeRequestStateEvents(projectID, mrIID int) ([]ResourceStateEvent, error) {
+	var mu sync.Mutex
+	events := make([]ResourceStateEvent, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListStateEventsOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glEvents, resp, err := c.gl.ResourceStateEvents.ListMergeStateEvents(projectID, mrIID, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, e := range glEvents {
+				events = append(events, convertResourceStateEvent(e))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing merge request state events for project %d mr !%d (page %d): %w", projectID, mrIID, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortByCreatedAt(events)
+	return events, nil
+}
+
+// ListIssueStateEvents fetches the state change history of an issue,
+// ordered oldest first.
+func (c *Client) ListIssueStateEvents(projectID, issueIID int) ([]ResourceStateEvent, error) {
+	var mu sync.Mutex
+	events := make([]ResourceStateEvent, 0)
+
+	err := c.paginate(func(page int) (int, error) {
+		opts := &gogitlab.ListStateEventsOptions{
+			ListOptions: gogitlab.ListOptions{Page: page, PerPage: c.cfg.PerPage},
+		}
+
+		resp, err := c.withRetry(func() (*gogitlab.Response, error) {
+			glEvents, resp, err := c.gl.ResourceStateEvents.ListIssueStateEvents(projectID, issueIID, opts)
+			if err != nil {
+				return resp, err
+			}
+			mu.Lock()
+			for _, e := range glEvents {
+				events = append(events, convertResourceStateEvent(e))
+			}
+			mu.Unlock()
+			return resp, nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("gitlab: listing issue state events for project %d issue #%d (page %d): %w", projectID, issueIID, page, err)
+		}
+		c.stats.recordMiss()
+		return resp.TotalPages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortByCreatedAt(events)
+	return events, nil
+}
+
+func convertResourceStateEvent(e *gogitlab.StateEvent) ResourceStateEvent {
+	return ResourceStateEvent{
+		State:     string(e.State),
+		CreatedAt: derefTime(e.CreatedAt),
+	}
+}
@@ -0,0 +1,29 @@
+package gitlab
+
+import "sync/atomic"
+
+// requestStats counts API requests a Client has made since the last
+// drain, partitioned by whether the response was served from cache (a
+// 304 Not Modified) or fetched fresh.
+type requestStats struct {
+	hits   int64
+	misses int64
+}
+
+func newRequestStats() *requestStats {
+	return &requestStats{}
+}
+
+func (s *requestStats) recordHit() {
+	atomic.AddInt64(&s.hits, 1)
+}
+
+func (s *requestStats) recordMiss() {
+	atomic.AddInt64(&s.misses, 1)
+}
+
+// drain returns the number of cache hits and misses recorded since the
+// last call to drain, resetting both counters to zero.
+func (s *requestStats) drain() (hits, misses int64) {
+	return atomic.SwapInt64(&s.hits, 0), atomic.SwapInt64(&s.misses, 0)
+}
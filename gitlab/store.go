@@ -0,0 +1,119 @@
+package gitlab
+
+import "sync"
+
+// projectStore holds the most recently seen version of every project,
+// keyed by ID, so a scrape that gets a 304 on the project list can still
+// report every project the exporter knows about.
+type projectStore struct {
+	mu       sync.Mutex
+	projects map[int]*Project
+}
+
+func newProjectStore() *projectStore {
+	return &projectStore{projects: make(map[int]*Project)}
+}
+
+// merge records any freshly fetched projects and returns every project
+// currently known, in no particular order.
+func (s *projectStore) merge(fresh []*Project) []*Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range fresh {
+		s.projects[p.Id] = p
+	}
+	return s.all()
+}
+
+func (s *projectStore) all() []*Project {
+	all := make([]*Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		all = append(all, p)
+	}
+	return all
+}
+
+// mergeRequestStore holds the most recently seen merge requests for each
+// project, keyed by project ID then merge request IID, so a scrape that
+// gets a 304 on a project's merge request list can still report every
+// merge request the exporter knows about for that project. It also
+// remembers which IIDs were part of the most recent merge() call, so
+// callers can tell freshly changed merge requests apart from ones that
+// are only being reported because they were cached.
+type mergeRequestStore struct {
+	mu        sync.Mutex
+	byProject map[int]map[int]MergeRequest
+	freshIids map[int]map[int]bool
+}
+
+func newMergeRequestStore() *mergeRequestStore {
+	return &mergeRequestStore{
+		byProject: make(map[int]map[int]MergeRequest),
+		freshIids: make(map[int]map[int]bool),
+	}
+}
+
+// merge records any freshly fetched merge requests for a project and
+// returns every merge request currently known for it, in no particular
+// order. The fresh IIDs are remembered so isFresh can report on them
+// until the next merge or all call for the project.
+func (s *mergeRequestStore) merge(projectID int, fresh []MergeRequest) []MergeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byIid, ok := s.byProject[projectID]
+	if !ok {
+		byIid = make(map[int]MergeRequest)
+		s.byProject[projectID] = byIid
+	}
+	freshIids := make(map[int]bool, len(fresh))
+	for _, mr := range fresh {
+		byIid[mr.Iid] = mr
+		freshIids[mr.Iid] = true
+	}
+	s.freshIids[projectID] = freshIids
+	return mergeRequestValues(byIid)
+}
+
+// all returns every merge request currently known for projectID without
+// fetching anything new, used when a scrape gets a 304. Nothing is
+// considered fresh in this case.
+func (s *mergeRequestStore) all(projectID int) []MergeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.freshIids[projectID] = nil
+	return mergeRequestValues(s.byProject[projectID])
+}
+
+// isFresh reports whether iid was part of the most recent merge() call
+// for projectID, i.e. it's new or changed since the previous scrape.
+func (s *mergeRequestStore) isFresh(projectID, iid int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.freshIids[projectID][iid]
+}
+
+// setStateEvents updates the stored state events for a merge request so
+// they keep being reported on later scrapes where it isn't fresh and its
+// state events aren't refetched.
+func (s *mergeRequestStore) setStateEvents(projectID, iid int, events []ResourceStateEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byIid, ok := s.byProject[projectID]
+	if !ok {
+		return
+	}
+	mr, ok := byIid[iid]
+	if !ok {
+		return
+	}
+	mr.StateEvents = events
+	byIid[iid] = mr
+}
+
+func mergeRequestValues(byIid map[int]MergeRequest) []MergeRequest {
+	values := make([]MergeRequest, 0, len(byIid))
+	for _, mr := range byIid {
+		values = append(values, mr)
+	}
+	return values
+}
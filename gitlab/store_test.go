@@ -0,0 +1,84 @@
+package gitlab
+
+import "testing"
+
+func TestProjectStoreMergeKeepsPreviouslySeenProjects(t *testing.T) {
+	s := newProjectStore()
+
+	s.merge([]*Project{{Id: 1, PathWithNamespace: "a/one"}})
+	all := s.merge([]*Project{{Id: 2, PathWithNamespace: "a/two"}})
+
+	if len(all) != 2 {
+		t.Fatalf("merge() returned %d projects, want 2", len(all))
+	}
+
+	// A later merge that only touches project 1 must not drop project 2.
+	all = s.merge([]*Project{{Id: 1, PathWithNamespace: "a/one-renamed"}})
+	if len(all) != 2 {
+		t.Fatalf("merge() returned %d projects, want 2", len(all))
+	}
+	for _, p := range all {
+		if p.Id == 1 && p.PathWithNamespace != "a/one-renamed" {
+			t.Errorf("project 1 PathWithNamespace = %q, want %q", p.PathWithNamespace, "a/one-renamed")
+		}
+	}
+}
+
+func TestMergeRequestStoreMergeTracksFreshness(t *testing.T) {
+	s := newMergeRequestStore()
+
+	s.merge(1, []MergeRequest{{Iid: 1}, {Iid: 2}})
+	if !s.isFresh(1, 1) || !s.isFresh(1, 2) {
+		t.Fatal("isFresh() = false for merge requests just merged, want true")
+	}
+
+	// Merging again with only IID 1 present must keep IID 2 in the
+	// store but stop reporting it as fresh.
+	all := s.merge(1, []MergeRequest{{Iid: 1}})
+	if len(all) != 2 {
+		t.Fatalf("merge() returned %d merge requests, want 2", len(all))
+	}
+	if !s.isFresh(1, 1) {
+		t.Error("isFresh(1, 1) = false, want true")
+	}
+	if s.isFresh(1, 2) {
+		t.Error("isFresh(1, 2) = true, want false")
+	}
+}
+
+func TestMergeRequestStoreAllClearsFreshness(t *testing.T) {
+	s := newMergeRequestStore()
+	s.merge(1, []MergeRequest{{Iid: 1}})
+
+	all := s.all(1)
+	if len(all) != 1 {
+		t.Fatalf("all() returned %d merge requests, want 1", len(all))
+	}
+	if s.isFresh(1, 1) {
+		t.Error("isFresh(1, 1) = true after all(), want false")
+	}
+}
+
+func TestMergeRequestStoreSetStateEventsPersistsAcrossMerges(t *testing.T) {
+	s := newMergeRequestStore()
+	s.merge(1, []MergeRequest{{Iid: 1}})
+
+	events := []ResourceStateEvent{{State: "opened"}, {State: "closed"}}
+	s.setStateEvents(1, 1, events)
+
+	// A later merge that doesn't include IID 1 must keep its state
+	// events intact.
+	all := s.merge(1, []MergeRequest{{Iid: 2}})
+	var found bool
+	for _, mr := range all {
+		if mr.Iid == 1 {
+			found = true
+			if len(mr.StateEvents) != 2 {
+				t.Errorf("merge request 1 has %d state events, want 2", len(mr.StateEvents))
+			}
+		}
+	}
+	if !found {
+		t.Fatal("merge request 1 missing from merge() result")
+	}
+}
@@ -0,0 +1,101 @@
+package gitlab
+
+import "time"
+
+// ProjectStats mirrors the `statistics` block returned by the Gitlab
+// projects API when `statistics=1` is requested.
+type ProjectStats struct {
+	CommitCount      int `json:"commit_count"`
+	StorageSize      int `json:"storage_size"`
+	RepositorySize   int `json:"repository_size"`
+	LfsObjectSize    int `json:"lfs_object_size"`
+	JobArtifactsSize int `json:"job_artifacts_size"`
+}
+
+// Project is a single Gitlab project enriched with the child resources
+// the exporter scrapes alongside it.
+type Project struct {
+	Id                int          `json:"id"`
+	PathWithNamespace string       `json:"path_with_namespace"`
+	StarCount         int          `json:"star_count"`
+	ForkCount         int          `json:"fork_count"`
+	OpenIssueCount    int          `json:"open_issues_count"`
+	LastActivityAt    time.Time    `json:"last_activity_at"`
+	Statistics        ProjectStats `json:"statistics"`
+
+	MergeRequests []MergeRequest
+	Issues        []Issue
+	Pipelines     []Pipeline
+	Releases      []Release
+	Environments  []Environment
+}
+
+type MergeRequest struct {
+	Iid          int       `json:"iid"`
+	Title        string    `json:"title"`
+	State        string    `json:"state"`
+	MergeStatus  string    `json:"merge_status"`
+	TargetBranch string    `json:"target_branch"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	StateEvents []ResourceStateEvent
+}
+
+type Issue struct {
+	Iid       int       `json:"iid"`
+	Title     string    `json:"title"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	StateEvents []ResourceStateEvent
+}
+
+type Pipeline struct {
+	Id        int       `json:"id"`
+	Status    string    `json:"status"`
+	Ref       string    `json:"ref"`
+	Sha       string    `json:"sha"`
+	Duration  int       `json:"duration"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Jobs           []Job
+	CommitStatuses []CommitStatus
+}
+
+type Job struct {
+	Id       int     `json:"id"`
+	Name     string  `json:"name"`
+	Stage    string  `json:"stage"`
+	Status   string  `json:"status"`
+	Duration float64 `json:"duration"`
+}
+
+type Release struct {
+	TagName    string    `json:"tag_name"`
+	Name       string    `json:"name"`
+	ReleasedAt time.Time `json:"released_at"`
+}
+
+type Environment struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+	State string `json:"state"`
+
+	Deployments []Deployment
+}
+
+type Deployment struct {
+	Id        int       `json:"id"`
+	Status    string    `json:"status"`
+	Ref       string    `json:"ref"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CommitStatus struct {
+	Sha    string `json:"sha"`
+	Status string `json:"status"`
+	Name   string `json:"name"`
+}
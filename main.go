@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/exporter-toolkit/web"
+
+	"github.com/apolikamixitos/prometheus-gitlab-exporter/collector"
+	"github.com/apolikamixitos/prometheus-gitlab-exporter/config"
+	"github.com/apolikamixitos/prometheus-gitlab-exporter/gitlab"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yml", "path to the exporter's YAML config file")
+	listenAddr := flag.String("web.listen-address", ":9168", "address to expose /metrics on")
+	webConfigFile := flag.String("web.config.file", "", "path to a web-config.yml enabling TLS and/or basic auth on the metrics listener (prometheus exporter-toolkit format)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("loading config: %v", err)
+	}
+
+	timeout, err := cfg.GitlabTimeout()
+	if err != nil {
+		log.Fatalf("loading config: %v", err)
+	}
+
+	client, err := gitlab.NewClient(gitlab.Config{
+		BaseURL:    cfg.GitlabURL,
+		Token:      cfg.Token,
+		AuthMethod: gitlab.AuthMethod(cfg.AuthMethod),
+		CACertFile: cfg.CACertFile,
+		ProxyURL:   cfg.ProxyURL,
+		Timeout:    timeout,
+	})
+	if err != nil {
+		log.Fatalf("creating gitlab client: %v", err)
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector.New(client, cfg))
+
+	http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{Addr: *listenAddr}
+	flagsConfig := &web.FlagConfig{
+		WebListenAddresses: &[]string{*listenAddr},
+		WebConfigFile:      webConfigFile,
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	log.Printf("listening on %s", *listenAddr)
+	log.Fatal(web.ListenAndServe(server, flagsConfig, logger))
+}